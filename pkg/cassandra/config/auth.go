@@ -0,0 +1,266 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/aws/aws-sigv4-auth-cassandra-gocql-driver-plugin/sigv4"
+	"github.com/gocql/gocql"
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/spf13/viper"
+)
+
+// KerberosAuthenticator holds the properties needed to authenticate against
+// a Cassandra cluster using Kerberos/GSSAPI SASL, e.g. DataStax Enterprise
+// with Kerberos enabled.
+type KerberosAuthenticator struct {
+	// Service is the Kerberos service principal name of the Cassandra cluster.
+	Service string `yaml:"service" mapstructure:"service"`
+	// Keytab is the path to the keytab file used to obtain a TGT for Username.
+	Keytab string `yaml:"keytab" mapstructure:"keytab"`
+	// Realm is the Kerberos realm to authenticate against.
+	Realm string `yaml:"realm" mapstructure:"realm"`
+	// Username is the Kerberos principal to authenticate as.
+	Username string `yaml:"username" mapstructure:"username"`
+	// UseCCache authenticates using the running user's credential cache
+	// (e.g. populated by kinit) instead of a keytab.
+	UseCCache bool `yaml:"use_ccache" mapstructure:"use_ccache"`
+}
+
+func (k *KerberosAuthenticator) isEmpty() bool {
+	return k.Keytab == "" && !k.UseCCache
+}
+
+// validate checks that the configured fields are internally consistent,
+// without touching disk or the network; newAuthenticator is what actually
+// loads the keytab/credential cache, at connection-build time.
+func (k *KerberosAuthenticator) validate() error {
+	if k.isEmpty() {
+		return nil
+	}
+	if k.Service == "" {
+		return errors.New("cassandra.authenticator.kerberos.service must be set")
+	}
+	if !k.UseCCache && k.Keytab == "" {
+		return errors.New("cassandra.authenticator.kerberos.keytab must be set unless use_ccache is true")
+	}
+	return nil
+}
+
+const (
+	prefixKerberosService   = ".authenticator.kerberos.service"
+	prefixKerberosKeytab    = ".authenticator.kerberos.keytab"
+	prefixKerberosRealm     = ".authenticator.kerberos.realm"
+	prefixKerberosUsername  = ".authenticator.kerberos.username"
+	prefixKerberosUseCCache = ".authenticator.kerberos.use-ccache"
+
+	prefixSigV4Region       = ".authenticator.sigv4.region"
+	prefixSigV4AccessKey    = ".authenticator.sigv4.access-key"
+	prefixSigV4SecretKey    = ".authenticator.sigv4.secret-key"
+	prefixSigV4SessionToken = ".authenticator.sigv4.session-token"
+)
+
+// AddFlags adds the Kerberos authenticator's CLI flags under namespace
+// (e.g. "cassandra" or "cassandra.archive"). Nothing in this package calls
+// it: binding it to the process flag set is the cassandra storage plugin's
+// options file, which isn't part of this package, so callers of cassandra's
+// storage.Factory get Kerberos only from YAML/mapstructure until that
+// wiring exists.
+func (k *KerberosAuthenticator) AddFlags(flagSet *flag.FlagSet, namespace string) {
+	flagSet.String(namespace+prefixKerberosService, "", "Kerberos service principal name of the Cassandra cluster")
+	flagSet.String(namespace+prefixKerberosKeytab, "", "Path to the keytab file used to authenticate, unless --"+namespace+prefixKerberosUseCCache+" is set")
+	flagSet.String(namespace+prefixKerberosRealm, "", "Kerberos realm to authenticate against")
+	flagSet.String(namespace+prefixKerberosUsername, "", "Kerberos principal to authenticate as")
+	flagSet.Bool(namespace+prefixKerberosUseCCache, false, "Authenticate using the credential cache populated by kinit instead of a keytab")
+}
+
+// InitFromViper initializes the Kerberos authenticator from viper, under the
+// same namespace passed to AddFlags.
+func (k *KerberosAuthenticator) InitFromViper(v *viper.Viper, namespace string) {
+	k.Service = v.GetString(namespace + prefixKerberosService)
+	k.Keytab = v.GetString(namespace + prefixKerberosKeytab)
+	k.Realm = v.GetString(namespace + prefixKerberosRealm)
+	k.Username = v.GetString(namespace + prefixKerberosUsername)
+	k.UseCCache = v.GetBool(namespace + prefixKerberosUseCCache)
+}
+
+func (k *KerberosAuthenticator) newAuthenticator() (gocql.Authenticator, error) {
+	krb5Cfg, err := config.Load(defaultKrb5ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load krb5.conf: %w", err)
+	}
+
+	var cl *client.Client
+	if k.UseCCache {
+		ccache, err := credentials.LoadCCache(defaultCCachePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kerberos credential cache: %w", err)
+		}
+		cl, err = client.NewFromCCache(ccache, krb5Cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build kerberos client from ccache: %w", err)
+		}
+	} else {
+		kt, err := keytab.Load(k.Keytab)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load keytab %s: %w", k.Keytab, err)
+		}
+		cl = client.NewWithKeytab(k.Username, k.Realm, kt, krb5Cfg)
+	}
+
+	return &gssapiAuthenticator{client: cl, service: k.Service}, nil
+}
+
+// gssapiAuthenticator implements gocql.Authenticator using a SASL GSSAPI
+// exchange, as required by Kerberos-enabled Cassandra clusters.
+type gssapiAuthenticator struct {
+	client  *client.Client
+	service string
+}
+
+// Challenge implements gocql.Authenticator. It logs the Kerberos client in
+// and returns the AP-REQ token the server expects as the SASL response.
+func (a *gssapiAuthenticator) Challenge(_ []byte) ([]byte, error) {
+	if err := a.client.Login(); err != nil {
+		return nil, fmt.Errorf("kerberos login failed: %w", err)
+	}
+	_, token, err := a.client.GetServiceTicket(a.service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain kerberos service ticket: %w", err)
+	}
+	return token.Marshal()
+}
+
+// Success implements gocql.Authenticator. Cassandra's GSSAPI SASL mechanism
+// does not send a final server token, so there is nothing to validate.
+func (*gssapiAuthenticator) Success([]byte) error {
+	return nil
+}
+
+const (
+	defaultKrb5ConfigPath = "/etc/krb5.conf"
+	defaultCCachePath     = "/tmp/krb5cc"
+)
+
+// SigV4Authenticator holds the properties needed to authenticate against
+// Amazon Keyspaces (for Apache Cassandra) using the AWS SigV4 plugin.
+type SigV4Authenticator struct {
+	Region string `yaml:"region" mapstructure:"region"`
+	// AccessKey and SecretKey are optional; when empty the default AWS
+	// credential chain (env vars, shared config, instance/task role) is used.
+	AccessKey    string `yaml:"access_key" mapstructure:"access_key"`
+	SecretKey    string `yaml:"secret_key" mapstructure:"secret_key" json:"-"`
+	SessionToken string `yaml:"session_token" mapstructure:"session_token" json:"-"`
+}
+
+func (s *SigV4Authenticator) isEmpty() bool {
+	return s.Region == ""
+}
+
+// validate checks that the configured fields are internally consistent,
+// without invoking the AWS credential chain; newAuthenticator is what
+// actually resolves credentials, at connection-build time.
+func (s *SigV4Authenticator) validate() error {
+	if s.isEmpty() {
+		return nil
+	}
+	if (s.AccessKey == "") != (s.SecretKey == "") {
+		return errors.New("cassandra.authenticator.sigv4.access_key and secret_key must be set together, or both left empty to use the default AWS credential chain")
+	}
+	return nil
+}
+
+// AddFlags adds the SigV4 authenticator's CLI flags under namespace
+// (e.g. "cassandra" or "cassandra.archive"). See KerberosAuthenticator's
+// AddFlags: this is likewise not called from anywhere in this package yet.
+func (s *SigV4Authenticator) AddFlags(flagSet *flag.FlagSet, namespace string) {
+	flagSet.String(namespace+prefixSigV4Region, "", "AWS region of the Amazon Keyspaces endpoint")
+	flagSet.String(namespace+prefixSigV4AccessKey, "", "AWS access key; leave empty with secret-key to use the default AWS credential chain")
+	flagSet.String(namespace+prefixSigV4SecretKey, "", "AWS secret key; leave empty with access-key to use the default AWS credential chain")
+	flagSet.String(namespace+prefixSigV4SessionToken, "", "AWS session token, for temporary credentials")
+}
+
+// InitFromViper initializes the SigV4 authenticator from viper, under the
+// same namespace passed to AddFlags.
+func (s *SigV4Authenticator) InitFromViper(v *viper.Viper, namespace string) {
+	s.Region = v.GetString(namespace + prefixSigV4Region)
+	s.AccessKey = v.GetString(namespace + prefixSigV4AccessKey)
+	s.SecretKey = v.GetString(namespace + prefixSigV4SecretKey)
+	s.SessionToken = v.GetString(namespace + prefixSigV4SessionToken)
+}
+
+func (s *SigV4Authenticator) newAuthenticator() gocql.Authenticator {
+	auth := sigv4.NewAwsAuthenticator(s.Region)
+	if s.AccessKey != "" {
+		auth.AccessKeyId = s.AccessKey
+	}
+	if s.SecretKey != "" {
+		auth.SecretAccessKey = s.SecretKey
+	}
+	if s.SessionToken != "" {
+		auth.SessionToken = s.SessionToken
+	}
+	return auth
+}
+
+// configuredCount returns how many of Basic, Kerberos, and SigV4 have been
+// given non-empty configuration.
+func (a *Authenticator) configuredCount() int {
+	configured := 0
+	if a.Basic.Username != "" && a.Basic.Password != "" {
+		configured++
+	}
+	if !a.Kerberos.isEmpty() {
+		configured++
+	}
+	if !a.SigV4.isEmpty() {
+		configured++
+	}
+	return configured
+}
+
+// validate performs cheap, local, side-effect-free checks: that at most one
+// authenticator is configured, and that whichever one is configured has its
+// required fields set. It deliberately does not load a keytab, read a
+// credential cache, or touch the AWS credential chain — that I/O belongs in
+// newAuthenticator, called once at connection-build time, not on every
+// config validation (e.g. in tests or CI).
+func (a *Authenticator) validate() error {
+	if a.configuredCount() > 1 {
+		return errors.New("only one of basic, kerberos, or sigv4 authentication may be configured")
+	}
+	if err := a.Kerberos.validate(); err != nil {
+		return err
+	}
+	return a.SigV4.validate()
+}
+
+// newAuthenticator selects whichever nested authenticator is configured,
+// returning an error if more than one is set.
+func (a *Authenticator) newAuthenticator() (gocql.Authenticator, error) {
+	if a.configuredCount() > 1 {
+		return nil, errors.New("only one of basic, kerberos, or sigv4 authentication may be configured")
+	}
+
+	switch {
+	case !a.Kerberos.isEmpty():
+		return a.Kerberos.newAuthenticator()
+	case !a.SigV4.isEmpty():
+		return a.SigV4.newAuthenticator(), nil
+	case a.Basic.Username != "" && a.Basic.Password != "":
+		return gocql.PasswordAuthenticator{
+			Username:              a.Basic.Username,
+			Password:              a.Basic.Password,
+			AllowedAuthenticators: a.Basic.AllowedAuthenticators,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -49,10 +49,13 @@ func DefaultConfiguration() Configuration {
 	}
 }
 
-// Authenticator holds the authentication properties needed to connect to a Cassandra cluster
+// Authenticator holds the authentication properties needed to connect to a Cassandra cluster.
+// At most one of Basic, Kerberos, or SigV4 may be configured; NewCluster returns
+// a validation error if more than one is non-empty.
 type Authenticator struct {
-	Basic BasicAuthenticator `yaml:"basic" mapstructure:",squash"`
-	// TODO: add more auth types
+	Basic    BasicAuthenticator    `yaml:"basic" mapstructure:",squash"`
+	Kerberos KerberosAuthenticator `yaml:"kerberos" mapstructure:"kerberos"`
+	SigV4    SigV4Authenticator    `yaml:"sigv4" mapstructure:"sigv4"`
 }
 
 // BasicAuthenticator holds the username and password for a password authenticator for a Cassandra cluster
@@ -143,13 +146,14 @@ func (c *Configuration) NewCluster(logger *zap.Logger) (*gocql.ClusterConfig, er
 	}
 	cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(fallbackHostSelectionPolicy, gocql.ShuffleReplicas())
 
-	if c.Authenticator.Basic.Username != "" && c.Authenticator.Basic.Password != "" {
-		cluster.Authenticator = gocql.PasswordAuthenticator{
-			Username:              c.Authenticator.Basic.Username,
-			Password:              c.Authenticator.Basic.Password,
-			AllowedAuthenticators: c.Authenticator.Basic.AllowedAuthenticators,
-		}
+	authenticator, err := c.Authenticator.newAuthenticator()
+	if err != nil {
+		return nil, err
 	}
+	if authenticator != nil {
+		cluster.Authenticator = authenticator
+	}
+
 	tlsCfg, err := c.TLS.Config(logger)
 	if err != nil {
 		return nil, err
@@ -176,6 +180,8 @@ func (c *Configuration) String() string {
 }
 
 func (c *Configuration) Validate() error {
-	_, err := govalidator.ValidateStruct(c)
-	return err
+	if _, err := govalidator.ValidateStruct(c); err != nil {
+		return err
+	}
+	return c.Authenticator.validate()
 }
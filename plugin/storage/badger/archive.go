@@ -0,0 +1,121 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package badger
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/metrics"
+	badgerStore "github.com/jaegertracing/jaeger/plugin/storage/badger/spanstore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+const (
+	archiveValueLogSpaceAvailableName = "badger_archive_value_log_bytes_available"
+	archiveKeyLogSpaceAvailableName   = "badger_archive_key_log_bytes_available"
+	archiveLastMaintenanceRunName     = "badger_archive_storage_maintenance_last_run"
+	archiveLastValueLogCleanedName    = "badger_archive_storage_valueloggc_last_run"
+)
+
+// initializeArchive opens the secondary Badger instance backing
+// storage.ArchiveFactory and starts its maintenance and metrics goroutines
+// alongside the primary store's.
+func (f *Factory) initializeArchive(metricsFactory metrics.Factory) error {
+	opts := badger.DefaultOptions("")
+
+	if f.Options.Archive.Ephemeral {
+		opts.SyncWrites = false
+		// Error from TempDir is ignored to satisfy Codecov
+		dir, _ := os.MkdirTemp("", "badger-archive")
+		f.archiveTmpDir = dir
+		opts.Dir = f.archiveTmpDir
+		opts.ValueDir = f.archiveTmpDir
+
+		f.Options.Archive.KeyDirectory = f.archiveTmpDir
+		f.Options.Archive.ValueDirectory = f.archiveTmpDir
+	} else {
+		// Errors are ignored as they're caught in the Open call
+		initializeDir(f.Options.Archive.KeyDirectory)
+		initializeDir(f.Options.Archive.ValueDirectory)
+
+		opts.Dir = f.Options.Archive.KeyDirectory
+		opts.ValueDir = f.Options.Archive.ValueDirectory
+	}
+
+	store, err := badger.Open(opts)
+	if err != nil {
+		return err
+	}
+	f.archiveStore = store
+	f.archiveTxnCoordinator = NewTxnCoordinator(metricsFactory, "archive")
+	f.archiveCache = badgerStore.NewCacheStore(f.archiveStore, f.Options.Archive.SpanStoreTTL, true)
+
+	f.archiveMetrics.ValueLogSpaceAvailable = metricsFactory.Gauge(metrics.Options{Name: archiveValueLogSpaceAvailableName})
+	f.archiveMetrics.KeyLogSpaceAvailable = metricsFactory.Gauge(metrics.Options{Name: archiveKeyLogSpaceAvailableName})
+	f.archiveMetrics.LastMaintenanceRun = metricsFactory.Gauge(metrics.Options{Name: archiveLastMaintenanceRunName})
+	f.archiveMetrics.LastValueLogCleaned = metricsFactory.Gauge(metrics.Options{Name: archiveLastValueLogCleanedName})
+
+	go f.archiveMaintenance()
+
+	return nil
+}
+
+// CreateArchiveSpanReader implements storage.ArchiveFactory
+func (f *Factory) CreateArchiveSpanReader() (spanstore.Reader, error) {
+	reader := spanstore.Reader(badgerStore.NewTraceReader(f.archiveStore, f.archiveCache))
+	return newCoordinatedReader(reader, f.archiveTxnCoordinator), nil
+}
+
+// CreateArchiveSpanWriter implements storage.ArchiveFactory
+func (f *Factory) CreateArchiveSpanWriter() (spanstore.Writer, error) {
+	writer := spanstore.Writer(badgerStore.NewSpanWriter(f.archiveStore, f.archiveCache, f.Options.Archive.SpanStoreTTL))
+	return newCoordinatedWriter(writer, f.archiveTxnCoordinator), nil
+}
+
+// archiveMaintenance runs the same ValueLogGC/disk-stats maintenance loop as
+// the primary store, against the archive DB, sharing the Factory's shutdown
+// signal.
+func (f *Factory) archiveMaintenance() {
+	maintenanceTicker := time.NewTicker(f.Options.Archive.MaintenanceInterval)
+	defer maintenanceTicker.Stop()
+	for {
+		select {
+		case <-f.maintenanceDone:
+			return
+		case t := <-maintenanceTicker.C:
+			var err error
+
+			// After there's nothing to clean, the err is raised
+			for err == nil {
+				err = f.archiveStore.RunValueLogGC(0.5)
+			}
+			if errors.Is(err, badger.ErrNoRewrite) {
+				f.archiveMetrics.LastValueLogCleaned.Update(t.UnixNano())
+			} else {
+				f.logger.Error("Failed to run archive ValueLogGC", zap.Error(err))
+			}
+
+			f.archiveMetrics.LastMaintenanceRun.Update(t.UnixNano())
+			f.archiveDiskStatisticsUpdate()
+		}
+	}
+}
+
+func (f *Factory) archiveDiskStatisticsUpdate() {
+	var keyStats, valueStats syscall.Statfs_t
+	if err := syscall.Statfs(f.Options.Archive.KeyDirectory, &keyStats); err == nil {
+		//nolint: gosec // G115
+		f.archiveMetrics.KeyLogSpaceAvailable.Update(int64(keyStats.Bavail * uint64(keyStats.Bsize)))
+	}
+	if err := syscall.Statfs(f.Options.Archive.ValueDirectory, &valueStats); err == nil {
+		//nolint: gosec // G115
+		f.archiveMetrics.ValueLogSpaceAvailable.Update(int64(valueStats.Bavail * uint64(valueStats.Bsize)))
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/badger/v4"
@@ -34,16 +35,16 @@ const (
 	keyLogSpaceAvailableName   = "badger_key_log_bytes_available"
 	lastMaintenanceRunName     = "badger_storage_maintenance_last_run"
 	lastValueLogCleanedName    = "badger_storage_valueloggc_last_run"
+	lastBackupRunName          = "badger_storage_backup_last_run"
+	lastBackupBytesName        = "badger_storage_backup_last_bytes"
 )
 
 var ( // interface comformance checks
-	_ storage.Factory     = (*Factory)(nil)
-	_ io.Closer           = (*Factory)(nil)
-	_ plugin.Configurable = (*Factory)(nil)
-	_ storage.Purger      = (*Factory)(nil)
-
-	// TODO badger could implement archive storage
-	// _ storage.ArchiveFactory       = (*Factory)(nil)
+	_ storage.Factory        = (*Factory)(nil)
+	_ io.Closer              = (*Factory)(nil)
+	_ plugin.Configurable    = (*Factory)(nil)
+	_ storage.Purger         = (*Factory)(nil)
+	_ storage.ArchiveFactory = (*Factory)(nil)
 
 	_ storage.SamplingStoreFactory = (*Factory)(nil)
 )
@@ -55,8 +56,29 @@ type Factory struct {
 	cache   *badgerStore.CacheStore
 	logger  *zap.Logger
 
+	// TxnCoordinator gives readers and writers atomic visibility of
+	// multi-key span/index writes; see TxnCoordinator for the locking
+	// regime it enforces.
+	TxnCoordinator *TxnCoordinator
+
 	tmpDir          string
 	maintenanceDone chan bool
+	snapshotDone    sync.WaitGroup
+
+	metricsFactory metrics.Factory
+
+	// archiveStore backs storage.ArchiveFactory with its own directories,
+	// TTL, and maintenance/metrics goroutines.
+	archiveStore          *badger.DB
+	archiveCache          *badgerStore.CacheStore
+	archiveTmpDir         string
+	archiveTxnCoordinator *TxnCoordinator
+	archiveMetrics        struct {
+		ValueLogSpaceAvailable metrics.Gauge
+		KeyLogSpaceAvailable   metrics.Gauge
+		LastMaintenanceRun     metrics.Gauge
+		LastValueLogCleaned    metrics.Gauge
+	}
 
 	// TODO initialize via reflection; convert comments to tag 'description'.
 	metrics struct {
@@ -68,6 +90,10 @@ type Factory struct {
 		LastMaintenanceRun metrics.Gauge
 		// LastValueLogCleaned stores the timestamp (UnixNano) of the previous ValueLogGC run
 		LastValueLogCleaned metrics.Gauge
+		// LastBackupRun stores the timestamp (UnixNano) of the previous snapshot backup
+		LastBackupRun metrics.Gauge
+		// LastBackupBytes stores the size in bytes of the previous snapshot backup
+		LastBackupBytes metrics.Gauge
 
 		// Expose badger's internal expvar metrics, which are all gauge's at this point
 		badgerMetrics map[string]metrics.Gauge
@@ -115,6 +141,7 @@ func (f *Factory) configureFromOptions(opts *Options) {
 // Initialize implements storage.Factory
 func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error {
 	f.logger = logger
+	f.metricsFactory = metricsFactory
 
 	opts := badger.DefaultOptions("")
 
@@ -147,18 +174,30 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 	}
 	f.store = store
 
+	f.TxnCoordinator = NewTxnCoordinator(metricsFactory, "primary")
 	f.cache = badgerStore.NewCacheStore(f.store, f.Options.Primary.SpanStoreTTL, true)
 
 	f.metrics.ValueLogSpaceAvailable = metricsFactory.Gauge(metrics.Options{Name: valueLogSpaceAvailableName})
 	f.metrics.KeyLogSpaceAvailable = metricsFactory.Gauge(metrics.Options{Name: keyLogSpaceAvailableName})
 	f.metrics.LastMaintenanceRun = metricsFactory.Gauge(metrics.Options{Name: lastMaintenanceRunName})
 	f.metrics.LastValueLogCleaned = metricsFactory.Gauge(metrics.Options{Name: lastValueLogCleanedName})
+	f.metrics.LastBackupRun = metricsFactory.Gauge(metrics.Options{Name: lastBackupRunName})
+	f.metrics.LastBackupBytes = metricsFactory.Gauge(metrics.Options{Name: lastBackupBytesName})
 
 	f.registerBadgerExpvarMetrics(metricsFactory)
 
 	go f.maintenance()
 	go f.metricsCopier()
 
+	if f.Options.Primary.BackupDir != "" {
+		f.snapshotDone.Add(1)
+		go f.snapshot()
+	}
+
+	if err := f.initializeArchive(metricsFactory); err != nil {
+		return err
+	}
+
 	logger.Info("Badger storage configuration", zap.Any("configuration", opts))
 
 	return nil
@@ -173,23 +212,41 @@ func initializeDir(path string) {
 
 // CreateSpanReader implements storage.Factory
 func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
-	return badgerStore.NewTraceReader(f.store, f.cache), nil
+	reader := spanstore.Reader(badgerStore.NewTraceReader(f.store, f.cache))
+	reader = newCoordinatedReader(reader, f.TxnCoordinator)
+	if f.Options.Primary.MetricsPerOperation {
+		reader = newInstrumentedReader(reader, f.metricsFactory)
+	}
+	return reader, nil
 }
 
 // CreateSpanWriter implements storage.Factory
 func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
-	return badgerStore.NewSpanWriter(f.store, f.cache, f.Options.Primary.SpanStoreTTL), nil
+	writer := spanstore.Writer(badgerStore.NewSpanWriter(f.store, f.cache, f.Options.Primary.SpanStoreTTL))
+	writer = newCoordinatedWriter(writer, f.TxnCoordinator)
+	if f.Options.Primary.MetricsPerOperation {
+		writer = newInstrumentedWriter(writer, f.metricsFactory)
+	}
+	return writer, nil
 }
 
 // CreateDependencyReader implements storage.Factory
 func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
 	sr, _ := f.CreateSpanReader() // err is always nil
-	return depStore.NewDependencyStore(sr), nil
+	reader := dependencystore.Reader(depStore.NewDependencyStore(sr))
+	if f.Options.Primary.MetricsPerOperation {
+		reader = newInstrumentedDependencyReader(reader, f.metricsFactory)
+	}
+	return reader, nil
 }
 
 // CreateSamplingStore implements storage.SamplingStoreFactory
 func (f *Factory) CreateSamplingStore(int /* maxBuckets */) (samplingstore.Store, error) {
-	return badgerSampling.NewSamplingStore(f.store), nil
+	store := samplingstore.Store(badgerSampling.NewSamplingStore(f.store))
+	if f.Options.Primary.MetricsPerOperation {
+		store = newInstrumentedSamplingStore(store, f.metricsFactory)
+	}
+	return store, nil
 }
 
 // CreateLock implements storage.SamplingStoreFactory
@@ -200,6 +257,7 @@ func (*Factory) CreateLock() (distributedlock.Lock, error) {
 // Close Implements io.Closer and closes the underlying storage
 func (f *Factory) Close() error {
 	close(f.maintenanceDone)
+	f.snapshotDone.Wait()
 	if f.store == nil {
 		return nil
 	}
@@ -213,6 +271,17 @@ func (f *Factory) Close() error {
 		}
 	}
 
+	if f.archiveStore != nil {
+		if errArchive := f.archiveStore.Close(); err == nil {
+			err = errArchive
+		}
+		if f.Options.Archive.Ephemeral {
+			if errSecondary := os.RemoveAll(f.archiveTmpDir); err == nil {
+				err = errSecondary
+			}
+		}
+	}
+
 	return err
 }
 
@@ -0,0 +1,223 @@
+// Copyright (c) 2019 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package badger
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixKeyDirectory          = ".directory-key"
+	prefixValueDirectory        = ".directory-value"
+	prefixEphemeral             = ".ephemeral"
+	prefixSpanStoreTTL          = ".span-store-ttl"
+	prefixSyncWrite             = ".consistency"
+	prefixMaintenanceInterval   = ".maintenance-interval"
+	prefixMetricsUpdateInterval = ".metrics-update-interval"
+	prefixReadOnly              = ".read-only"
+
+	prefixBackupDir       = ".backup.dir"
+	prefixBackupInterval  = ".backup.interval"
+	prefixBackupRetention = ".backup.retention"
+
+	prefixMetricsPerOperation = ".metrics.per_operation"
+
+	defaultBackupInterval  = time.Hour
+	defaultBackupRetention = 24
+
+	archiveNamespace  = "badger.archive"
+	defaultArchiveTTL = 720 * time.Hour
+)
+
+// NamespaceConfig holds the configuration for a single badger namespace
+// (primary or archive storage).
+type NamespaceConfig struct {
+	namespace string
+
+	SpanStoreTTL          time.Duration
+	Ephemeral             bool
+	KeyDirectory          string
+	ValueDirectory        string
+	SyncWrites            bool
+	MaintenanceInterval   time.Duration
+	MetricsUpdateInterval time.Duration
+	ReadOnly              bool
+
+	// BackupDir is the local directory that periodic snapshots are written
+	// to, one file per backup via badger's DB.Backup. An empty value
+	// disables snapshotting. There is no S3 or other remote sink; writing
+	// one would mean streaming through badger's Stream API instead of
+	// DB.Backup, which runSnapshot does not do.
+	BackupDir string
+	// BackupInterval controls how often a new incremental backup is taken.
+	BackupInterval time.Duration
+	// BackupRetention is the number of incremental backups to retain before
+	// the oldest is pruned.
+	BackupRetention int
+
+	// MetricsPerOperation enables per-method request/error/latency metrics
+	// on the span, dependency, and sampling stores. Disabling it keeps
+	// metric cardinality low on the default expvar gauges only.
+	MetricsPerOperation bool
+}
+
+// Options stores the configuration entries for this storage
+type Options struct {
+	Primary NamespaceConfig `mapstructure:",squash"`
+	// Archive holds the configuration for the separate, long-retention
+	// Badger instance used to serve storage.ArchiveFactory.
+	Archive NamespaceConfig `mapstructure:"archive"`
+}
+
+// NewOptions creates a new Options struct.
+func NewOptions(primaryNamespace string) *Options {
+	return &Options{
+		Primary: NamespaceConfig{
+			namespace:             primaryNamespace,
+			SpanStoreTTL:          72 * time.Hour,
+			Ephemeral:             true,
+			SyncWrites:            false,
+			MaintenanceInterval:   5 * time.Minute,
+			MetricsUpdateInterval: 10 * time.Second,
+			BackupInterval:        defaultBackupInterval,
+			BackupRetention:       defaultBackupRetention,
+			MetricsPerOperation:   true,
+		},
+		Archive: NamespaceConfig{
+			namespace:             archiveNamespace,
+			SpanStoreTTL:          defaultArchiveTTL,
+			Ephemeral:             true,
+			MaintenanceInterval:   5 * time.Minute,
+			MetricsUpdateInterval: 10 * time.Second,
+		},
+	}
+}
+
+// AddFlags from this storage to the CLI
+func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
+	addFlags(flagSet, opt.Primary)
+	addArchiveFlags(flagSet, opt.Archive)
+}
+
+func addFlags(flagSet *flag.FlagSet, nsConfig NamespaceConfig) {
+	flagSet.Bool(
+		nsConfig.namespace+prefixEphemeral,
+		nsConfig.Ephemeral,
+		"Mark this storage ephemeral, data is stored in tmpfs (accepts true, false)")
+	flagSet.Duration(
+		nsConfig.namespace+prefixSpanStoreTTL,
+		nsConfig.SpanStoreTTL,
+		"How long to store the data. Format is time.Duration (https://golang.org/pkg/time/#Duration)")
+	flagSet.String(
+		nsConfig.namespace+prefixKeyDirectory,
+		"/tmp/badger/keys",
+		"Path to store the keys (indexes), this directory should reside in fast storage, e.g. SSD")
+	flagSet.String(
+		nsConfig.namespace+prefixValueDirectory,
+		"/tmp/badger/data",
+		"Path to store the values (spans data), this directory can reside in slower storage, e.g. HDD")
+	flagSet.Bool(
+		nsConfig.namespace+prefixSyncWrite,
+		nsConfig.SyncWrites,
+		"If all writes should be synced immediately to physical disk. This will impact write performance.")
+	flagSet.Duration(
+		nsConfig.namespace+prefixMaintenanceInterval,
+		nsConfig.MaintenanceInterval,
+		"How often the maintenance thread for the badger store runs")
+	flagSet.Duration(
+		nsConfig.namespace+prefixMetricsUpdateInterval,
+		nsConfig.MetricsUpdateInterval,
+		"How often the badger metrics are computed, this is a heavy operation when using the default value")
+	flagSet.Bool(
+		nsConfig.namespace+prefixReadOnly,
+		nsConfig.ReadOnly,
+		"Allows to open badger database in read only mode. Multiple instances can open same database in read-only mode. Values still in the write-ahead-log must be replayed before opening.")
+	flagSet.String(
+		nsConfig.namespace+prefixBackupDir,
+		"",
+		"Local directory to write periodic incremental backups to. Disabled when empty.")
+	flagSet.Duration(
+		nsConfig.namespace+prefixBackupInterval,
+		nsConfig.BackupInterval,
+		"How often a new incremental backup is streamed out, when --badger.backup.dir is set")
+	flagSet.Int(
+		nsConfig.namespace+prefixBackupRetention,
+		nsConfig.BackupRetention,
+		"Number of incremental backups to retain, older ones are pruned")
+	flagSet.Bool(
+		nsConfig.namespace+prefixMetricsPerOperation,
+		nsConfig.MetricsPerOperation,
+		"Whether to emit per-operation request, error, and latency metrics, in addition to the default gauges. Disable to reduce metric cardinality.")
+}
+
+// addArchiveFlags registers only the subset of flags relevant to the archive
+// namespace; unlike the primary store it has no backup, consistency, or
+// read-only knobs of its own.
+func addArchiveFlags(flagSet *flag.FlagSet, nsConfig NamespaceConfig) {
+	flagSet.Bool(
+		nsConfig.namespace+prefixEphemeral,
+		nsConfig.Ephemeral,
+		"Mark the archive storage ephemeral, data is stored in tmpfs (accepts true, false)")
+	flagSet.Duration(
+		nsConfig.namespace+prefixSpanStoreTTL,
+		nsConfig.SpanStoreTTL,
+		"How long to store the archive data. Format is time.Duration (https://golang.org/pkg/time/#Duration)")
+	flagSet.String(
+		nsConfig.namespace+prefixKeyDirectory,
+		"/tmp/badger/archive/keys",
+		"Path to store the archive keys (indexes), this directory should reside in fast storage, e.g. SSD")
+	flagSet.String(
+		nsConfig.namespace+prefixValueDirectory,
+		"/tmp/badger/archive/data",
+		"Path to store the archive values (spans data), this directory can reside in slower storage, e.g. HDD")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opt *Options) InitFromViper(v *viper.Viper, _ *zap.Logger) {
+	initFromViper(&opt.Primary, v)
+	initArchiveFromViper(&opt.Archive, v)
+}
+
+// GetArchive returns the archive namespace configuration
+func (opt *Options) GetArchive() *NamespaceConfig {
+	return &opt.Archive
+}
+
+func initFromViper(cfg *NamespaceConfig, v *viper.Viper) {
+	cfg.Ephemeral = v.GetBool(cfg.namespace + prefixEphemeral)
+	cfg.SpanStoreTTL = v.GetDuration(cfg.namespace + prefixSpanStoreTTL)
+	cfg.SyncWrites = v.GetBool(cfg.namespace + prefixSyncWrite)
+	cfg.MaintenanceInterval = v.GetDuration(cfg.namespace + prefixMaintenanceInterval)
+	cfg.MetricsUpdateInterval = v.GetDuration(cfg.namespace + prefixMetricsUpdateInterval)
+	cfg.ReadOnly = v.GetBool(cfg.namespace + prefixReadOnly)
+	cfg.ValueDirectory = v.GetString(cfg.namespace + prefixValueDirectory)
+	cfg.KeyDirectory = v.GetString(cfg.namespace + prefixKeyDirectory)
+	cfg.BackupDir = v.GetString(cfg.namespace + prefixBackupDir)
+	cfg.BackupInterval = v.GetDuration(cfg.namespace + prefixBackupInterval)
+	cfg.BackupRetention = v.GetInt(cfg.namespace + prefixBackupRetention)
+	cfg.MetricsPerOperation = v.GetBool(cfg.namespace + prefixMetricsPerOperation)
+}
+
+// initArchiveFromViper reads only the flags addArchiveFlags actually
+// registers. It must not share initFromViper's full field list: the archive
+// namespace has no bound maintenance-interval/metrics-update-interval/
+// consistency/read-only/backup/metrics.per_operation flags, so reading those
+// keys from viper would silently zero out NewOptions' archive defaults (most
+// importantly MaintenanceInterval, whose zero value makes archiveMaintenance
+// panic on time.NewTicker).
+func initArchiveFromViper(cfg *NamespaceConfig, v *viper.Viper) {
+	cfg.Ephemeral = v.GetBool(cfg.namespace + prefixEphemeral)
+	cfg.SpanStoreTTL = v.GetDuration(cfg.namespace + prefixSpanStoreTTL)
+	cfg.ValueDirectory = v.GetString(cfg.namespace + prefixValueDirectory)
+	cfg.KeyDirectory = v.GetString(cfg.namespace + prefixKeyDirectory)
+}
+
+// GetPrimary returns the primary namespace configuration
+func (opt *Options) GetPrimary() *NamespaceConfig {
+	return &opt.Primary
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package badger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/metrics"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// TxnCoordinator enforces the single-writer/multi-reader locking regime
+// adopted by other Badger-on-Go projects: any number of reads may run
+// concurrently, at most one write is in flight at a time, and a queued
+// write blocks new reads and waits for in-flight reads to finish. This
+// gives callers atomic visibility of multi-key writes that would otherwise
+// be partially observable by concurrent reads.
+type TxnCoordinator struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	activeReaders int
+	writerActive  bool
+	queuedReaders int
+	queuedWriters int
+
+	queuedReadersGauge metrics.Gauge
+	queuedWritersGauge metrics.Gauge
+	readWaitTimer      metrics.Timer
+	writeWaitTimer     metrics.Timer
+}
+
+// NewTxnCoordinator creates a TxnCoordinator that reports queue depth and
+// wait-time fairness metrics through metricsFactory, tagged with store so
+// the primary and archive instances (each with their own TxnCoordinator)
+// don't alias onto the same series.
+func NewTxnCoordinator(metricsFactory metrics.Factory, store string) *TxnCoordinator {
+	tags := map[string]string{"store": store}
+	c := &TxnCoordinator{
+		queuedReadersGauge: metricsFactory.Gauge(metrics.Options{Name: "badger_txn_queued_readers", Tags: tags}),
+		queuedWritersGauge: metricsFactory.Gauge(metrics.Options{Name: "badger_txn_queued_writers", Tags: tags}),
+		readWaitTimer:      metricsFactory.Timer(metrics.TimerOptions{Name: "badger_txn_read_wait", Tags: tags}),
+		writeWaitTimer:     metricsFactory.Timer(metrics.TimerOptions{Name: "badger_txn_write_wait", Tags: tags}),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// AcquireRead blocks while a writer is queued or committing, then admits the
+// read. Callers must call ReleaseRead when the read transaction is done.
+func (c *TxnCoordinator) AcquireRead() {
+	start := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queuedReaders++
+	c.queuedReadersGauge.Update(int64(c.queuedReaders))
+	for c.writerActive || c.queuedWriters > 0 {
+		c.cond.Wait()
+	}
+	c.queuedReaders--
+	c.queuedReadersGauge.Update(int64(c.queuedReaders))
+
+	c.activeReaders++
+	c.readWaitTimer.Record(time.Since(start))
+}
+
+// ReleaseRead releases a read transaction acquired via AcquireRead.
+func (c *TxnCoordinator) ReleaseRead() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.activeReaders--
+	c.cond.Broadcast()
+}
+
+// AcquireWrite blocks until it is safe to start the single in-flight write:
+// no other write is running, and then until all active readers have
+// finished. Callers must call Commit exactly once, even on failure, to
+// release the write slot.
+func (c *TxnCoordinator) AcquireWrite() {
+	start := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queuedWriters++
+	c.queuedWritersGauge.Update(int64(c.queuedWriters))
+	for c.writerActive {
+		c.cond.Wait()
+	}
+	c.writerActive = true
+	for c.activeReaders > 0 {
+		c.cond.Wait()
+	}
+	c.queuedWriters--
+	c.queuedWritersGauge.Update(int64(c.queuedWriters))
+	c.writeWaitTimer.Record(time.Since(start))
+}
+
+// Commit runs fn to persist the write while no reads are admitted, then
+// releases the write slot so queued readers and writers can proceed.
+func (c *TxnCoordinator) Commit(fn func() error) error {
+	err := fn()
+
+	c.mu.Lock()
+	c.writerActive = false
+	c.mu.Unlock()
+
+	c.cond.Broadcast()
+	return err
+}
+
+// coordinatedReader wraps a spanstore.Reader so every method runs under
+// coordinator's read lock, guaranteeing it never overlaps with an in-flight
+// write commit.
+type coordinatedReader struct {
+	reader      spanstore.Reader
+	coordinator *TxnCoordinator
+}
+
+func newCoordinatedReader(reader spanstore.Reader, coordinator *TxnCoordinator) *coordinatedReader {
+	return &coordinatedReader{reader: reader, coordinator: coordinator}
+}
+
+func (r *coordinatedReader) GetTrace(ctx context.Context, traceID model.TraceID) (*model.Trace, error) {
+	r.coordinator.AcquireRead()
+	defer r.coordinator.ReleaseRead()
+	return r.reader.GetTrace(ctx, traceID)
+}
+
+func (r *coordinatedReader) GetServices(ctx context.Context) ([]string, error) {
+	r.coordinator.AcquireRead()
+	defer r.coordinator.ReleaseRead()
+	return r.reader.GetServices(ctx)
+}
+
+func (r *coordinatedReader) GetOperations(ctx context.Context, query spanstore.OperationQueryParameters) ([]spanstore.Operation, error) {
+	r.coordinator.AcquireRead()
+	defer r.coordinator.ReleaseRead()
+	return r.reader.GetOperations(ctx, query)
+}
+
+func (r *coordinatedReader) FindTraces(ctx context.Context, query *spanstore.TraceQueryParameters) ([]*model.Trace, error) {
+	r.coordinator.AcquireRead()
+	defer r.coordinator.ReleaseRead()
+	return r.reader.FindTraces(ctx, query)
+}
+
+func (r *coordinatedReader) FindTraceIDs(ctx context.Context, query *spanstore.TraceQueryParameters) ([]model.TraceID, error) {
+	r.coordinator.AcquireRead()
+	defer r.coordinator.ReleaseRead()
+	return r.reader.FindTraceIDs(ctx, query)
+}
+
+// coordinatedWriter wraps a spanstore.Writer so every write runs as the
+// coordinator's single in-flight write transaction, blocked behind any
+// active reads and committed under coordinator's write lock before any
+// queued reads or writes are admitted.
+type coordinatedWriter struct {
+	writer      spanstore.Writer
+	coordinator *TxnCoordinator
+}
+
+func newCoordinatedWriter(writer spanstore.Writer, coordinator *TxnCoordinator) *coordinatedWriter {
+	return &coordinatedWriter{writer: writer, coordinator: coordinator}
+}
+
+func (w *coordinatedWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	w.coordinator.AcquireWrite()
+	return w.coordinator.Commit(func() error {
+		return w.writer.WriteSpan(ctx, span)
+	})
+}
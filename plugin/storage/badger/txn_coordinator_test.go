@@ -0,0 +1,99 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package badger
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/internal/metricstest"
+)
+
+func TestTxnCoordinator_WriteExcludesActiveReader(t *testing.T) {
+	c := NewTxnCoordinator(metricstest.NewFactory(0), "test")
+
+	var activeReaders int32
+	var overlapped int32
+
+	c.AcquireRead()
+	atomic.AddInt32(&activeReaders, 1)
+
+	writeStarted := make(chan struct{})
+	writeDone := make(chan struct{})
+	go func() {
+		c.AcquireWrite()
+		close(writeStarted)
+		if atomic.LoadInt32(&activeReaders) != 0 {
+			atomic.StoreInt32(&overlapped, 1)
+		}
+		_ = c.Commit(func() error { return nil })
+		close(writeDone)
+	}()
+
+	select {
+	case <-writeStarted:
+		t.Fatal("AcquireWrite returned while a read transaction was still active")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	atomic.AddInt32(&activeReaders, -1)
+	c.ReleaseRead()
+
+	select {
+	case <-writeStarted:
+	case <-time.After(time.Second):
+		t.Fatal("AcquireWrite never unblocked after ReleaseRead")
+	}
+	<-writeDone
+
+	assert.Zero(t, atomic.LoadInt32(&overlapped), "write ran concurrently with an active read")
+}
+
+func TestTxnCoordinator_ReadBlocksBehindQueuedWriter(t *testing.T) {
+	c := NewTxnCoordinator(metricstest.NewFactory(0), "test")
+
+	c.AcquireRead()
+
+	writeAcquired := make(chan struct{})
+	go func() {
+		c.AcquireWrite()
+		close(writeAcquired)
+		_ = c.Commit(func() error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		})
+	}()
+
+	// Give the writer a chance to queue up before the only active read releases.
+	time.Sleep(10 * time.Millisecond)
+	c.ReleaseRead()
+
+	select {
+	case <-writeAcquired:
+	case <-time.After(time.Second):
+		t.Fatal("queued write never acquired once the blocking read released")
+	}
+
+	readAdmitted := make(chan struct{})
+	go func() {
+		c.AcquireRead()
+		close(readAdmitted)
+		c.ReleaseRead()
+	}()
+
+	select {
+	case <-readAdmitted:
+		t.Fatal("new read was admitted while a write was still committing")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	select {
+	case <-readAdmitted:
+	case <-time.After(time.Second):
+		t.Fatal("read was never admitted after the write committed")
+	}
+}